@@ -0,0 +1,24 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nativetest provides a struct living outside the ext package, used by
+// ext's tests to exercise discovery of a target package's types through a wrapper
+// struct defined in another package entirely.
+package nativetest
+
+// Leaf is a plain struct with no relation to the ext package, embedded by wrapper
+// structs in ext's tests.
+type Leaf struct {
+	Name string
+}
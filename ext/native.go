@@ -29,6 +29,7 @@ import (
 	"github.com/google/cel-go/common/types/pb"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/interpreter/functions"
 
 	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	structpb "google.golang.org/protobuf/types/known/structpb"
@@ -79,9 +80,32 @@ var (
 // same advice holds if you are using custom type adapters and type providers. The native type
 // provider composes over whichever type adapter and provider is configured in the cel.Env at
 // the time that it is invoked.
-func NativeTypes(refTypes ...any) cel.EnvOption {
+//
+// A struct field may be renamed or hidden from CEL with a `cel` struct tag, e.g. a field tagged
+// `cel:"myField"` is addressed as `myField` rather than its Go name, and a field tagged
+// `cel:"-"` is not visible to CEL at all. Pass the NativeTypesUseJSONTag option to fall back to
+// the `json` struct tag when no `cel` tag is present, mirroring encoding/json's own precedence.
+//
+// Pass the NativeTypesWithMethods option to additionally expose the exported methods of a
+// registered type as CEL member functions, e.g. a method `func (a *Account) Age() int` becomes
+// callable from CEL as `account.Age()`.
+//
+// Exported fields promoted from an anonymous (embedded) struct field are surfaced at the top
+// level, the same way encoding/json promotes them. Interface-typed fields are exposed with
+// their declared CEL type set to dyn, since the concrete type is only known once a value is
+// available. For an interface-typed field tagged `omitempty`, `has()` treats a nil interface as
+// unset and a non-nil interface as set, even when the concrete value it holds is a zero value; a
+// field without `omitempty`, interface-typed or not, is always reported as set.
+//
+// Rather than listing every type individually, NativeTypesFromPackage registers an entire
+// package's worth of struct types at once, starting from one or more exemplar instances.
+//
+// NativeEnum registers a named Go integer or string constant, such as a `type Status int`, as a
+// symbolic namespace, so that a field of that type can be compared against names like
+// `pkg.Status.Active` instead of a hardcoded magic value.
+func NativeTypes(args ...any) cel.EnvOption {
 	return func(env *cel.Env) (*cel.Env, error) {
-		tp, err := newNativeTypeProvider(env.TypeAdapter(), env.TypeProvider(), refTypes...)
+		tp, err := newNativeTypeProvider(env.TypeAdapter(), env.TypeProvider(), args...)
 		if err != nil {
 			return nil, err
 		}
@@ -89,55 +113,336 @@ func NativeTypes(refTypes ...any) cel.EnvOption {
 		if err != nil {
 			return nil, err
 		}
-		return cel.CustomTypeProvider(tp)(env)
+		env, err = cel.CustomTypeProvider(tp)(env)
+		if err != nil {
+			return nil, err
+		}
+		if !tp.useMethods {
+			return env, nil
+		}
+		var methodDecls []*exprpb.Decl
+		var overloads []*functions.Overload
+		for _, t := range tp.nativeTypes {
+			for _, m := range t.methods {
+				methodDecls = append(methodDecls, decls.NewFunction(m.name,
+					decls.NewInstanceOverload(m.overloadID, m.argTypes, m.resultType)))
+				overloads = append(overloads, tp.bindMethod(m))
+			}
+		}
+		env, err = cel.Declarations(methodDecls...)(env)
+		if err != nil {
+			return nil, err
+		}
+		return cel.Lib(nativeMethodsLib{overloads: overloads})(env)
+	}
+}
+
+// nativeMethodsLib binds the method overloads discovered by NativeTypesWithMethods onto every
+// Program produced from the configured Env, the same way cel.Functions would for a single
+// Program, since a plain ProgramOption cannot be applied directly from within an EnvOption.
+type nativeMethodsLib struct {
+	overloads []*functions.Overload
+}
+
+// CompileOptions implements the cel.Library interface method; method dispatch requires no
+// additional compile-time configuration beyond the declarations NativeTypes already registers.
+func (l nativeMethodsLib) CompileOptions() []cel.EnvOption {
+	return nil
+}
+
+// ProgramOptions implements the cel.Library interface method.
+func (l nativeMethodsLib) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{cel.Functions(l.overloads...)}
+}
+
+// NativeTypesOption configures how the NativeTypes provider registers and exposes Go types.
+type NativeTypesOption func(*nativeTypeProvider) (*nativeTypeProvider, error)
+
+// NativeTypesUseJSONTag configures the native type provider to fall back to a struct field's
+// `json` struct tag for its CEL field name and options whenever a `cel` tag is not present,
+// mirroring the tag precedence used by encoding/json.
+func NativeTypesUseJSONTag() NativeTypesOption {
+	return func(tp *nativeTypeProvider) (*nativeTypeProvider, error) {
+		tp.useJSONTag = true
+		return tp, nil
+	}
+}
+
+// NativeTypesWithMethods configures the native type provider to expose the exported methods of
+// registered types as CEL member functions, in addition to their exported fields. A variadic
+// method is called from CEL with a single list argument in place of its variadic parameter, e.g.
+// `func (c *Calc) Sum(ns ...int64) int64` is called as `c.Sum([1, 2, 3])`; see methodSignature.
+func NativeTypesWithMethods() NativeTypesOption {
+	return func(tp *nativeTypeProvider) (*nativeTypeProvider, error) {
+		tp.useMethods = true
+		return tp, nil
+	}
+}
+
+// NativeTypesFromPackage registers every struct type reachable from the given seeds whose
+// package path equals pkgPath, so that a handful of exemplar types is enough to pull in an
+// entire related domain model rather than requiring each type to be listed individually. Each
+// seed may be a reflect.Type, a reflect.Value, or a struct (or pointer to struct) instance.
+// Starting from each seed, struct fields (and the element types of any slice, array, map, or
+// pointer) are walked transitively, with a visited set keyed by reflect.Type guarding against
+// cycles.
+func NativeTypesFromPackage(pkgPath string, seeds ...any) NativeTypesOption {
+	return func(tp *nativeTypeProvider) (*nativeTypeProvider, error) {
+		visited := make(map[reflect.Type]bool)
+		var found []reflect.Type
+		for _, seed := range seeds {
+			var refType reflect.Type
+			switch s := seed.(type) {
+			case reflect.Type:
+				refType = s
+			case reflect.Value:
+				refType = s.Type()
+			default:
+				refType = reflect.TypeOf(seed)
+			}
+			discoverPackageTypes(pkgPath, refType, visited, &found)
+		}
+		// Building the nativeType instances is deferred to newNativeTypeProvider, which runs
+		// after every NativeTypesOption has been applied, so that an option such as
+		// NativeTypesUseJSONTag appearing later in the same NativeTypes call still takes effect.
+		tp.pkgTypes = append(tp.pkgTypes, found...)
+		return tp, nil
+	}
+}
+
+// discoverPackageTypes walks refType and its reachable struct fields, appending to found every
+// struct type (other than one already visited) whose PkgPath equals pkgPath. A struct whose own
+// PkgPath does not match, such as a third-party or standard library wrapper, is still walked for
+// matching fields nested within it; only whether it is itself added to found is gated on the
+// match.
+func discoverPackageTypes(pkgPath string, refType reflect.Type, visited map[reflect.Type]bool, found *[]reflect.Type) {
+	if refType == nil {
+		return
+	}
+	switch refType.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		discoverPackageTypes(pkgPath, refType.Elem(), visited, found)
+	case reflect.Map:
+		discoverPackageTypes(pkgPath, refType.Key(), visited, found)
+		discoverPackageTypes(pkgPath, refType.Elem(), visited, found)
+	case reflect.Struct:
+		if visited[refType] {
+			return
+		}
+		visited[refType] = true
+		if refType.PkgPath() == pkgPath {
+			*found = append(*found, refType)
+		}
+		for i := 0; i < refType.NumField(); i++ {
+			discoverPackageTypes(pkgPath, refType.Field(i).Type, visited, found)
+		}
+	}
+}
+
+// NativeEnum registers a Go integer or string constant namespace, such as a `type Status int`
+// with a handful of `const` values, so that it can be referenced symbolically from CEL, e.g.
+// `pkg.Status.Active`, rather than forcing callers to hardcode the underlying magic values.
+// zeroValue provides the enum's underlying Go type and its package, while values maps each
+// member's unqualified name to its concrete value; every entry in values must share zeroValue's
+// type. A struct field of the same underlying type still type-checks as its usual CEL int or
+// string type; NativeEnum only adds the symbolic names alongside it.
+//
+// A qualified member reference such as `pkg.Status.Active` is resolved by the CEL checker's own
+// enum-import fallback, which (as of the cel-go version this package is built against) assumes
+// the resolved value is always a 64-bit int; referencing a string, uint, or float-kind member
+// this way compiles fine through this package but panics inside the checker itself. Int-kind
+// enums such as the `type Status int` example above are unaffected. Until that assumption is
+// relaxed upstream, prefer int-kind enums for members meant to be referenced as bare identifiers;
+// string/uint/float-kind members are still computed correctly and safe to use via direct
+// ref.TypeProvider.EnumValue lookups.
+func NativeEnum(zeroValue any, values map[string]any) NativeTypesOption {
+	return func(tp *nativeTypeProvider) (*nativeTypeProvider, error) {
+		refType := reflect.TypeOf(zeroValue)
+		if refType == nil {
+			return nil, fmt.Errorf("NativeEnum requires a non-nil zero value")
+		}
+		enum := &nativeEnum{
+			typeName: fmt.Sprintf("%s.%s", simplePkgAlias(refType.PkgPath()), refType.Name()),
+			refType:  refType,
+			values:   make(map[string]ref.Val, len(values)),
+		}
+		for name, val := range values {
+			if reflect.TypeOf(val) != refType {
+				return nil, fmt.Errorf("enum value %s.%s must be of type %v", enum.typeName, name, refType)
+			}
+			enumVal, err := nativeEnumValue(refType.Kind(), val)
+			if err != nil {
+				return nil, fmt.Errorf("enum value %s.%s: %w", enum.typeName, name, err)
+			}
+			enum.values[name] = enumVal
+		}
+		tp.enums[enum.typeName] = enum
+		return tp, nil
+	}
+}
+
+// nativeEnumValue converts a named Go constant to the ref.Val it represents, based on the
+// constant's underlying kind. This is done explicitly rather than delegating to
+// nativeTypeProvider.NativeToValue, because the generic reflection-based adapter it eventually
+// falls back to only special-cases a handful of numeric kinds for named types, and would
+// otherwise produce an error value for an enum with a string or plain int/uint underlying type.
+func nativeEnumValue(kind reflect.Kind, val any) (ref.Val, error) {
+	refVal := reflect.ValueOf(val)
+	switch kind {
+	case reflect.String:
+		return types.String(refVal.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return types.Int(refVal.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return types.Uint(refVal.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return types.Double(refVal.Float()), nil
+	}
+	return nil, fmt.Errorf("unsupported enum underlying kind %v, must be string, int, uint, or float", kind)
+}
+
+// nativeEnum is the CEL-visible namespace for a NativeEnum registration; it resolves via
+// FindIdent so that `pkg.Status` is a valid (if otherwise inert) identifier, while its member
+// values are resolved individually through nativeTypeProvider.EnumValue.
+type nativeEnum struct {
+	typeName string
+	refType  reflect.Type
+	values   map[string]ref.Val
+}
+
+// ConvertToNative implements ref.Val.ConvertToNative.
+func (e *nativeEnum) ConvertToNative(typeDesc reflect.Type) (any, error) {
+	return nil, fmt.Errorf("type conversion error for type to '%v'", typeDesc)
+}
+
+// ConvertToType implements ref.Val.ConvertToType.
+func (e *nativeEnum) ConvertToType(typeVal ref.Type) ref.Val {
+	if typeVal == types.TypeType {
+		return types.TypeType
 	}
+	return types.NewErr("type conversion error from '%s' to '%s'", types.TypeType, typeVal)
+}
+
+// Equal returns true if both type names are equal to each other.
+func (e *nativeEnum) Equal(other ref.Val) ref.Val {
+	otherType, ok := other.(ref.Type)
+	return types.Bool(ok && e.TypeName() == otherType.TypeName())
+}
+
+// HasTrait implements the ref.Type interface method; enum namespaces support no traits.
+func (e *nativeEnum) HasTrait(trait int) bool {
+	return false
+}
+
+// String implements the strings.Stringer interface method.
+func (e *nativeEnum) String() string {
+	return e.typeName
 }
 
-func newNativeTypeProvider(adapter ref.TypeAdapter, provider ref.TypeProvider, refTypes ...any) (*nativeTypeProvider, error) {
-	nativeTypes := make(map[string]*nativeType, len(refTypes))
+// Type implements the ref.Val interface method.
+func (e *nativeEnum) Type() ref.Type {
+	return types.TypeType
+}
+
+// TypeName implements the ref.Type interface method.
+func (e *nativeEnum) TypeName() string {
+	return e.typeName
+}
+
+// Value implements the ref.Val interface method.
+func (e *nativeEnum) Value() any {
+	return e.typeName
+}
+
+func newNativeTypeProvider(adapter ref.TypeAdapter, provider ref.TypeProvider, args ...any) (*nativeTypeProvider, error) {
+	tp := &nativeTypeProvider{
+		nativeTypes:  make(map[string]*nativeType),
+		enums:        make(map[string]*nativeEnum),
+		baseAdapter:  adapter,
+		baseProvider: provider,
+	}
+	var refTypes []any
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case NativeTypesOption:
+			var err error
+			tp, err = v(tp)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			refTypes = append(refTypes, v)
+		}
+	}
 	for _, refType := range refTypes {
 		switch rt := refType.(type) {
 		case reflect.Type:
-			t, err := newNativeType(rt)
+			t, err := newNativeType(tp.useJSONTag, rt)
 			if err != nil {
 				return nil, err
 			}
-			nativeTypes[t.TypeName()] = t
+			tp.nativeTypes[t.TypeName()] = t
 		case reflect.Value:
-			t, err := newNativeType(rt.Type())
+			t, err := newNativeType(tp.useJSONTag, rt.Type())
 			if err != nil {
 				return nil, err
 			}
-			nativeTypes[t.TypeName()] = t
+			tp.nativeTypes[t.TypeName()] = t
 		default:
 			return nil, fmt.Errorf("unsupported native type: %v (%T) must be reflect.Type or reflect.Value", rt, rt)
 		}
 	}
-	return &nativeTypeProvider{
-		nativeTypes:  nativeTypes,
-		baseAdapter:  adapter,
-		baseProvider: provider,
-	}, nil
+	for _, refType := range tp.pkgTypes {
+		t, err := newNativeType(tp.useJSONTag, refType)
+		if err != nil {
+			return nil, err
+		}
+		tp.nativeTypes[t.TypeName()] = t
+	}
+	if tp.useMethods {
+		for _, t := range tp.nativeTypes {
+			t.methods = methodOverloads(t.typeName, t.refType)
+		}
+	}
+	return tp, nil
 }
 
 type nativeTypeProvider struct {
 	nativeTypes  map[string]*nativeType
+	enums        map[string]*nativeEnum
 	baseAdapter  ref.TypeAdapter
 	baseProvider ref.TypeProvider
+	useJSONTag   bool
+	useMethods   bool
+	// pkgTypes holds reflect.Type values discovered by NativeTypesFromPackage whose nativeType
+	// construction is deferred until every NativeTypesOption has run, the same way useMethods
+	// defers method discovery, so that later options still apply to them.
+	pkgTypes []reflect.Type
 }
 
-// EnumValue proxies to the ref.TypeProvider configured at the times the NativeTypes
-// option was configured.
+// EnumValue resolves a fully-qualified enum value identifier, such as "pkg.Status.Active",
+// registered via NativeEnum, and if not found proxies to the composed ref.TypeProvider.
 func (tp *nativeTypeProvider) EnumValue(enumName string) ref.Val {
+	idx := strings.LastIndex(enumName, ".")
+	if idx >= 0 {
+		if enum, found := tp.enums[enumName[:idx]]; found {
+			if val, found := enum.values[enumName[idx+1:]]; found {
+				return val
+			}
+		}
+	}
 	return tp.baseProvider.EnumValue(enumName)
 }
 
-// FindIdent looks up natives type instances by qualified identifier, and if not found
-// proxies to the composed ref.TypeProvider.
+// FindIdent looks up native type instances and NativeEnum namespaces by qualified identifier,
+// and if not found proxies to the composed ref.TypeProvider.
 func (tp *nativeTypeProvider) FindIdent(typeName string) (ref.Val, bool) {
 	if t, found := tp.nativeTypes[typeName]; found {
 		return t, true
 	}
+	if e, found := tp.enums[typeName]; found {
+		return e, true
+	}
 	return tp.baseProvider.FindIdent(typeName)
 }
 
@@ -157,11 +462,11 @@ func (tp *nativeTypeProvider) FindFieldType(typeName, fieldName string) (*ref.Fi
 	if !found {
 		return tp.baseProvider.FindFieldType(typeName, fieldName)
 	}
-	refField, isDefined := t.hasField(fieldName)
+	field, isDefined := t.hasField(fieldName)
 	if !found || !isDefined {
 		return nil, false
 	}
-	exprType, ok := convertToExprType(refField.Type)
+	exprType, ok := convertToExprType(field.Type)
 	if !ok {
 		return nil, false
 	}
@@ -169,12 +474,12 @@ func (tp *nativeTypeProvider) FindFieldType(typeName, fieldName string) (*ref.Fi
 		Type: exprType,
 		IsSet: func(obj any) bool {
 			refVal := reflect.Indirect(reflect.ValueOf(obj))
-			refField := refVal.FieldByName(fieldName)
-			return !refField.IsZero()
+			refField := fieldByIndex(refVal, field.Index, false)
+			return refField.IsValid() && isFieldSet(refField, field.omitempty)
 		},
 		GetFrom: func(obj any) (any, error) {
 			refVal := reflect.Indirect(reflect.ValueOf(obj))
-			refField := refVal.FieldByName(fieldName)
+			refField := fieldByIndex(refVal, field.Index, false)
 			return getFieldValue(tp, refField), nil
 		},
 	}, true
@@ -189,15 +494,15 @@ func (tp *nativeTypeProvider) NewValue(typeName string, fields map[string]ref.Va
 	refPtr := reflect.New(t.refType)
 	refVal := refPtr.Elem()
 	for fieldName, val := range fields {
-		refFieldDef, isDefined := t.hasField(fieldName)
+		field, isDefined := t.hasField(fieldName)
 		if !isDefined {
 			return types.NewErr("no such field: %s", fieldName)
 		}
-		fieldVal, err := val.ConvertToNative(refFieldDef.Type)
+		fieldVal, err := val.ConvertToNative(field.Type)
 		if err != nil {
 			return types.NewErr(err.Error())
 		}
-		refField := refVal.FieldByIndex(refFieldDef.Index)
+		refField := fieldByIndex(refVal, field.Index, true)
 		refFieldVal := reflect.ValueOf(fieldVal)
 		refField.Set(refFieldVal)
 	}
@@ -236,7 +541,7 @@ func (tp *nativeTypeProvider) NativeToValue(val any) ref.Val {
 			time.Time:
 			return tp.baseAdapter.NativeToValue(val)
 		default:
-			return newNativeObject(tp, val, rawVal)
+			return tp.nativeObject(val, rawVal)
 		}
 	default:
 		return tp.baseAdapter.NativeToValue(val)
@@ -293,17 +598,21 @@ func convertToExprType(refType reflect.Type) (*exprpb.Type, bool) {
 			return decls.NewObjectType(string(pbMsg.ProtoReflect().Descriptor().FullName())), true
 		}
 		return convertToExprType(refType.Elem())
+	case reflect.Interface:
+		// The concrete type held by an interface-typed field is only known at access time, so
+		// it is exposed to the type-checker as dyn and resolved dynamically via NativeToValue.
+		return decls.Dyn, true
 	}
 	return nil, false
 }
 
-func newNativeObject(adapter ref.TypeAdapter, val any, refValue reflect.Value) ref.Val {
-	valType, err := newNativeType(refValue.Type())
+func (tp *nativeTypeProvider) nativeObject(val any, refValue reflect.Value) ref.Val {
+	valType, err := newNativeType(tp.useJSONTag, refValue.Type())
 	if err != nil {
 		return types.NewErr(err.Error())
 	}
 	return &nativeObj{
-		TypeAdapter: adapter,
+		TypeAdapter: tp,
 		val:         val,
 		valType:     valType,
 		refValue:    refValue,
@@ -342,20 +651,25 @@ func (o *nativeObj) ConvertToNative(typeDesc reflect.Type) (any, error) {
 		return structpb.NewStructValue(jsonStruct.(*structpb.Struct)), nil
 	case jsonStructType:
 		refVal := reflect.Indirect(o.refValue)
-		refType := refVal.Type()
-		fields := make(map[string]*structpb.Value, refVal.NumField())
-		for i := 0; i < refVal.NumField(); i++ {
-			fieldType := refType.Field(i)
-			fieldValue := refVal.Field(i)
-			if !fieldValue.IsValid() || fieldValue.IsZero() {
+		fields := make(map[string]*structpb.Value, len(o.valType.fieldMap))
+		for celName, field := range o.valType.fieldMap {
+			fieldValue := fieldByIndex(refVal, field.Index, false)
+			if !fieldValue.IsValid() || !isFieldSet(fieldValue, field.omitempty) {
 				continue
 			}
+			if fieldValue.Kind() == reflect.Interface {
+				if fieldValue.IsNil() {
+					fields[celName] = structpb.NewNullValue()
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
 			fieldCelVal := o.NativeToValue(fieldValue.Interface())
 			fieldJsonVal, err := fieldCelVal.ConvertToNative(jsonValueType)
 			if err != nil {
 				return nil, err
 			}
-			fields[fieldType.Name] = fieldJsonVal.(*structpb.Value)
+			fields[celName] = fieldJsonVal.(*structpb.Value)
 		}
 		return &structpb.Struct{Fields: fields}, nil
 	}
@@ -407,34 +721,34 @@ func (o *nativeObj) IsZeroValue() bool {
 
 // IsSet tests whether a field which is defined is set to a non-default value.
 func (o *nativeObj) IsSet(field ref.Val) ref.Val {
-	refField, refErr := o.getReflectedField(field)
+	refField, refFieldDef, refErr := o.getReflectedField(field)
 	if refErr != nil {
 		return refErr
 	}
-	return types.Bool(!refField.IsZero())
+	return types.Bool(refField.IsValid() && isFieldSet(refField, refFieldDef.omitempty))
 }
 
 // Get returns the value fo a field name.
 func (o *nativeObj) Get(field ref.Val) ref.Val {
-	refField, refErr := o.getReflectedField(field)
+	refField, _, refErr := o.getReflectedField(field)
 	if refErr != nil {
 		return refErr
 	}
 	return adaptFieldValue(o, refField)
 }
 
-func (o *nativeObj) getReflectedField(field ref.Val) (reflect.Value, ref.Val) {
+func (o *nativeObj) getReflectedField(field ref.Val) (reflect.Value, *nativeField, ref.Val) {
 	fieldName, ok := field.(types.String)
 	if !ok {
-		return reflect.Value{}, types.MaybeNoSuchOverloadErr(field)
+		return reflect.Value{}, nil, types.MaybeNoSuchOverloadErr(field)
 	}
 	fieldNameStr := string(fieldName)
 	refField, isDefined := o.valType.hasField(fieldNameStr)
 	if !isDefined {
-		return reflect.Value{}, types.NewErr("no such field: %s", fieldName)
+		return reflect.Value{}, nil, types.NewErr("no such field: %s", fieldName)
 	}
 	refVal := reflect.Indirect(o.refValue)
-	return refVal.FieldByIndex(refField.Index), nil
+	return fieldByIndex(refVal, refField.Index, false), refField, nil
 }
 
 // Type implements the ref.Val interface method.
@@ -447,7 +761,7 @@ func (o *nativeObj) Value() any {
 	return o.val
 }
 
-func newNativeType(rawType reflect.Type) (*nativeType, error) {
+func newNativeType(useJSONTag bool, rawType reflect.Type) (*nativeType, error) {
 	refType := rawType
 	if refType.Kind() == reflect.Pointer {
 		refType = refType.Elem()
@@ -458,12 +772,255 @@ func newNativeType(rawType reflect.Type) (*nativeType, error) {
 	return &nativeType{
 		typeName: fmt.Sprintf("%s.%s", simplePkgAlias(refType.PkgPath()), refType.Name()),
 		refType:  refType,
+		fieldMap: makeFieldMap(refType, useJSONTag),
 	}, nil
 }
 
 type nativeType struct {
 	typeName string
 	refType  reflect.Type
+	fieldMap map[string]*nativeField
+	methods  []*nativeMethod
+}
+
+// nativeField associates a reflect.StructField with the CEL-specific tag options which control
+// its visibility and zero-value semantics.
+type nativeField struct {
+	reflect.StructField
+	omitempty bool
+}
+
+// makeFieldMap builds a map of CEL field name to nativeField for all of the exported, CEL-
+// supported fields of refType, honoring `cel` struct tags (falling back to `json` tags when
+// useJSONTag is enabled) for field renaming and omission, much like encoding/json. Anonymous
+// struct fields (including through a pointer) that carry no tag at all are flattened so their
+// exported fields are promoted to the top level, the same way encoding/json promotes them; a
+// promoted name never overrides one already declared at a shallower level.
+func makeFieldMap(refType reflect.Type, useJSONTag bool) map[string]*nativeField {
+	fieldMap := make(map[string]*nativeField, refType.NumField())
+	var promote []reflect.StructField
+	for i := 0; i < refType.NumField(); i++ {
+		f := refType.Field(i)
+		name, omitempty, hidden, hasTag := fieldTagOptions(f, useJSONTag)
+		if f.Anonymous && !hasTag {
+			if _, ok := structElemType(f.Type); ok {
+				promote = append(promote, f)
+				continue
+			}
+		}
+		if !f.IsExported() || !isSupportedType(f.Type) || hidden {
+			continue
+		}
+		fieldMap[name] = &nativeField{StructField: f, omitempty: omitempty}
+	}
+	for _, f := range promote {
+		embeddedType, _ := structElemType(f.Type)
+		for name, promoted := range makeFieldMap(embeddedType, useJSONTag) {
+			if _, exists := fieldMap[name]; exists {
+				continue
+			}
+			nf := *promoted
+			nf.Index = append(append([]int{}, f.Index...), promoted.Index...)
+			fieldMap[name] = &nf
+		}
+	}
+	return fieldMap
+}
+
+// structElemType returns the struct type embedded by a field, unwrapping a single pointer
+// indirection, and reports whether the field's type is in fact a struct (or pointer to one).
+func structElemType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		return t, true
+	}
+	return nil, false
+}
+
+// fieldByIndex walks a chain of struct field indices as recorded by makeFieldMap for promoted
+// fields, dereferencing embedded pointers along the way. A nil pointer encountered mid-chain
+// yields the zero Value unless alloc is true, in which case it is allocated so construction can
+// continue through it.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// isFieldSet reports whether a field should be considered present for has()/IsSet purposes. A
+// field without the `omitempty` tag option is always considered set, matching Golang's own zero
+// value not being a special case for ordinary struct access; an `omitempty` field instead falls
+// back to a zero-value check, treating a nil interface as unset regardless of the zero value of
+// whatever concrete type it might otherwise hold.
+func isFieldSet(refField reflect.Value, omitempty bool) bool {
+	if !omitempty {
+		return true
+	}
+	if refField.Kind() == reflect.Interface {
+		return !refField.IsNil()
+	}
+	return !refField.IsZero()
+}
+
+// fieldTagOptions parses the `cel` struct tag (falling back to `json` when useJSONTag is
+// enabled and no `cel` tag is present) and returns the CEL-visible field name, whether the
+// field should be considered omitempty, whether the field should be hidden entirely, and
+// whether an explicit tag was present at all.
+func fieldTagOptions(f reflect.StructField, useJSONTag bool) (name string, omitempty bool, hidden bool, hasTag bool) {
+	tag, ok := f.Tag.Lookup("cel")
+	if !ok && useJSONTag {
+		tag, ok = f.Tag.Lookup("json")
+	}
+	name = f.Name
+	if !ok || tag == "" {
+		return name, false, false, false
+	}
+	hasTag = true
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return name, false, true, hasTag
+	}
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false, hasTag
+}
+
+// nativeMethod describes a Go method exposed to CEL as a member function overload.
+type nativeMethod struct {
+	name       string
+	overloadID string
+	method     reflect.Method
+	argTypes   []*exprpb.Type
+	resultType *exprpb.Type
+	hasError   bool
+	variadic   bool
+}
+
+// methodOverloads walks the method set of a pointer to refType and returns a nativeMethod for
+// every exported method whose signature can be fully represented in CEL, skipping the rest.
+func methodOverloads(typeName string, refType reflect.Type) []*nativeMethod {
+	ptrType := reflect.PointerTo(refType)
+	var methods []*nativeMethod
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		m := ptrType.Method(i)
+		if !m.IsExported() {
+			continue
+		}
+		argTypes, resultType, hasError, ok := methodSignature(m)
+		if !ok {
+			continue
+		}
+		methods = append(methods, &nativeMethod{
+			name:       m.Name,
+			overloadID: fmt.Sprintf("%s_%s", typeName, m.Name),
+			method:     m,
+			argTypes:   argTypes,
+			resultType: resultType,
+			hasError:   hasError,
+			variadic:   m.Func.Type().IsVariadic(),
+		})
+	}
+	return methods
+}
+
+// methodSignature converts a method's receiver and input parameters to CEL argument types, with
+// the receiver as argTypes[0], and its first return value to a CEL result type. A trailing
+// `error` return is permitted and reported via hasError. Methods with any other return shape, or
+// whose parameter or result types cannot be represented in CEL, are rejected with ok == false.
+//
+// A variadic parameter such as `xs ...int` is declared using its raw Go type (`[]int`), so it
+// type-checks as a single CEL list(int) argument rather than as a variadic CEL argument list: the
+// method must be called as `recv.Method([1, 2, 3])`, not `recv.Method(1, 2, 3)`. bindMethod
+// dispatches such a call with reflect.Value.CallSlice, passing the CEL list straight through as
+// the method's variadic slice.
+func methodSignature(m reflect.Method) (argTypes []*exprpb.Type, resultType *exprpb.Type, hasError bool, ok bool) {
+	fnType := m.Func.Type()
+	numOut := fnType.NumOut()
+	if numOut == 0 || numOut > 2 {
+		return nil, nil, false, false
+	}
+	if numOut == 2 {
+		if fnType.Out(1) != errorType {
+			return nil, nil, false, false
+		}
+		hasError = true
+	}
+	resultType, ok = convertToExprType(fnType.Out(0))
+	if !ok {
+		return nil, nil, false, false
+	}
+	argTypes = make([]*exprpb.Type, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		argType, ok := convertToExprType(fnType.In(i))
+		if !ok {
+			return nil, nil, false, false
+		}
+		argTypes[i] = argType
+	}
+	return argTypes, resultType, hasError, true
+}
+
+// bindMethod returns the runtime dispatcher for a nativeMethod: it adapts the receiver and
+// arguments from CEL values to Go values, invokes the method via reflection, and adapts the
+// result (or error) back to a CEL value.
+func (tp *nativeTypeProvider) bindMethod(m *nativeMethod) *functions.Overload {
+	return &functions.Overload{
+		Operator: m.overloadID,
+		Function: func(args ...ref.Val) ref.Val {
+			recvObj, ok := args[0].(*nativeObj)
+			if !ok {
+				return types.MaybeNoSuchOverloadErr(args[0])
+			}
+			recvVal := recvObj.refValue
+			if recvVal.Kind() != reflect.Pointer {
+				ptr := reflect.New(recvVal.Type())
+				ptr.Elem().Set(recvVal)
+				recvVal = ptr
+			}
+			fnType := m.method.Func.Type()
+			methodArgs := make([]reflect.Value, len(args)-1)
+			for i, arg := range args[1:] {
+				goType := fnType.In(i + 1)
+				nativeArg, err := arg.ConvertToNative(goType)
+				if err != nil {
+					return types.NewErr(err.Error())
+				}
+				methodArgs[i] = reflect.ValueOf(nativeArg)
+			}
+			method := recvVal.MethodByName(m.name)
+			var out []reflect.Value
+			if m.variadic {
+				out = method.CallSlice(methodArgs)
+			} else {
+				out = method.Call(methodArgs)
+			}
+			if m.hasError {
+				if errVal := out[len(out)-1]; !errVal.IsNil() {
+					return types.NewErr(errVal.Interface().(error).Error())
+				}
+				out = out[:len(out)-1]
+			}
+			return tp.NativeToValue(out[0].Interface())
+		},
+	}
 }
 
 // ConvertToNative implements ref.Val.ConvertToNative.
@@ -511,11 +1068,12 @@ func (t *nativeType) Value() any {
 	return t.typeName
 }
 
-// hasField returns whether a field name has a corresponding Golang reflect.StructField
-func (t *nativeType) hasField(fieldName string) (reflect.StructField, bool) {
-	f, found := t.refType.FieldByName(fieldName)
-	if !found || !f.IsExported() || !isSupportedType(f.Type) {
-		return reflect.StructField{}, false
+// hasField returns whether a CEL field name has a corresponding Golang reflect.StructField,
+// accounting for any `cel`/`json` tag-driven renaming or omission configured on the type.
+func (t *nativeType) hasField(fieldName string) (*nativeField, bool) {
+	f, found := t.fieldMap[fieldName]
+	if !found {
+		return nil, false
 	}
 	return f, true
 }
@@ -525,6 +1083,15 @@ func adaptFieldValue(adapter ref.TypeAdapter, refField reflect.Value) ref.Val {
 }
 
 func getFieldValue(adapter ref.TypeAdapter, refField reflect.Value) any {
+	if !refField.IsValid() {
+		return nil
+	}
+	if refField.Kind() == reflect.Interface {
+		if refField.IsNil() {
+			return nil
+		}
+		refField = refField.Elem()
+	}
 	if refField.IsZero() {
 		switch refField.Kind() {
 		case reflect.Array, reflect.Slice:
@@ -571,4 +1138,5 @@ var (
 	pbMsgInterfaceType = reflect.TypeOf((*protoreflect.ProtoMessage)(nil)).Elem()
 	timestampType      = reflect.TypeOf(time.Now())
 	durationType       = reflect.TypeOf(time.Nanosecond)
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
 )
@@ -0,0 +1,593 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ext
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+
+	"github.com/google/cel-go/ext/internal/nativetest"
+)
+
+// evalNativeExpr compiles and evaluates expr against env, the same Parse/Check/Program/Eval path
+// every caller of NativeTypes actually drives through a real cel.Env.
+func evalNativeExpr(t *testing.T, env *cel.Env, expr string, vars map[string]any) (interface{}, error) {
+	t.Helper()
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+type e2eTagged struct {
+	Visible string `cel:"renamed"`
+	Hidden  string `cel:"-"`
+	Plain   string
+}
+
+func TestNativeTypesTagsE2E(t *testing.T) {
+	env, err := cel.NewEnv(
+		NativeTypes(reflect.TypeOf(e2eTagged{})),
+		cel.Declarations(decls.NewVar("x", decls.NewObjectType("ext.e2eTagged"))),
+	)
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	tests := []struct {
+		name    string
+		expr    string
+		vars    map[string]any
+		want    any
+		wantErr string
+	}{
+		{
+			name: "tag rename",
+			expr: "x.renamed",
+			vars: map[string]any{"x": &e2eTagged{Visible: "hi", Plain: "p"}},
+			want: "hi",
+		},
+		{
+			name: "plain field keeps go name",
+			expr: "x.Plain",
+			vars: map[string]any{"x": &e2eTagged{Plain: "p"}},
+			want: "p",
+		},
+		{
+			name:    "hidden field inaccessible",
+			expr:    "has(x.Hidden)",
+			wantErr: "Hidden",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evalNativeExpr(t, env, tc.expr, tc.vars)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got (%v, %v), want error containing %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("eval(%q) failed: %v", tc.expr, err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("eval(%q) = %v, want %v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFieldTagOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		tag        reflect.StructTag
+		useJSONTag bool
+		wantName   string
+		wantOmit   bool
+		wantHidden bool
+		wantHasTag bool
+	}{
+		{name: "no tag", tag: "", wantName: "Field"},
+		{name: "cel rename", tag: `cel:"myField"`, wantName: "myField", wantHasTag: true},
+		{name: "cel hide", tag: `cel:"-"`, wantName: "Field", wantHidden: true, wantHasTag: true},
+		{name: "cel omitempty", tag: `cel:"myField,omitempty"`, wantName: "myField", wantOmit: true, wantHasTag: true},
+		{name: "json fallback", tag: `json:"jsonField"`, useJSONTag: true, wantName: "jsonField", wantHasTag: true},
+		{name: "json ignored without useJSONTag", tag: `json:"jsonField"`, wantName: "Field"},
+		{name: "cel takes priority over json", tag: `cel:"celField" json:"jsonField"`, useJSONTag: true, wantName: "celField", wantHasTag: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := reflect.StructField{Name: "Field", Tag: tc.tag}
+			name, omitempty, hidden, hasTag := fieldTagOptions(f, tc.useJSONTag)
+			if name != tc.wantName || omitempty != tc.wantOmit || hidden != tc.wantHidden || hasTag != tc.wantHasTag {
+				t.Errorf("fieldTagOptions(%q, %v) = (%q, %v, %v, %v), want (%q, %v, %v, %v)",
+					tc.tag, tc.useJSONTag, name, omitempty, hidden, hasTag,
+					tc.wantName, tc.wantOmit, tc.wantHidden, tc.wantHasTag)
+			}
+		})
+	}
+}
+
+type nativeTestEmbedded struct {
+	Inner string `cel:"inner"`
+}
+
+type nativeTestTagged struct {
+	Visible string `cel:"renamed"`
+	Hidden  string `cel:"-"`
+	Plain   string
+	nativeTestEmbedded
+}
+
+func TestMakeFieldMapTagsAndPromotion(t *testing.T) {
+	fieldMap := makeFieldMap(reflect.TypeOf(nativeTestTagged{}), false)
+	if _, ok := fieldMap["renamed"]; !ok {
+		t.Error("expected field tagged cel:\"renamed\" to be addressable as renamed")
+	}
+	if _, ok := fieldMap["Hidden"]; ok {
+		t.Error("expected field tagged cel:\"-\" to be hidden")
+	}
+	if _, ok := fieldMap["Plain"]; !ok {
+		t.Error("expected untagged field to keep its Go name")
+	}
+	if _, ok := fieldMap["inner"]; !ok {
+		t.Error("expected tagged field of an embedded struct to be promoted to the top level")
+	}
+}
+
+func TestIsFieldSet(t *testing.T) {
+	var s string
+	refField := reflect.ValueOf(&s).Elem()
+	if !isFieldSet(refField, false) {
+		t.Error("a field without omitempty must always report set")
+	}
+	if isFieldSet(refField, true) {
+		t.Error("a zero-value omitempty field must report unset")
+	}
+	s = "hello"
+	if !isFieldSet(refField, true) {
+		t.Error("a non-zero omitempty field must report set")
+	}
+}
+
+type nativeTestGreeter struct {
+	Name string
+}
+
+func (g *nativeTestGreeter) Greet(prefix string) string {
+	return prefix + g.Name
+}
+
+func TestMethodOverloads(t *testing.T) {
+	methods := methodOverloads("test.Greeter", reflect.TypeOf(nativeTestGreeter{}))
+	var greet *nativeMethod
+	for _, m := range methods {
+		if m.name == "Greet" {
+			greet = m
+		}
+	}
+	if greet == nil {
+		t.Fatal("expected Greet to be discovered as a CEL-callable method")
+	}
+	if len(greet.argTypes) != 2 {
+		t.Errorf("expected 2 arg types (receiver and prefix), got %d", len(greet.argTypes))
+	}
+}
+
+func TestBindMethodDispatch(t *testing.T) {
+	tp, err := newNativeTypeProvider(types.DefaultTypeAdapter, nil,
+		NativeTypesWithMethods(), reflect.TypeOf(nativeTestGreeter{}))
+	if err != nil {
+		t.Fatalf("newNativeTypeProvider() failed: %v", err)
+	}
+	typeName := fmt.Sprintf("%s.nativeTestGreeter", simplePkgAlias(reflect.TypeOf(nativeTestGreeter{}).PkgPath()))
+	nt, found := tp.nativeTypes[typeName]
+	if !found {
+		t.Fatalf("expected %s to be registered", typeName)
+	}
+	var greet *nativeMethod
+	for _, m := range nt.methods {
+		if m.name == "Greet" {
+			greet = m
+		}
+	}
+	if greet == nil {
+		t.Fatal("expected Greet method on nativeTestGreeter")
+	}
+	recv := tp.NativeToValue(&nativeTestGreeter{Name: "Bob"})
+	result := tp.bindMethod(greet).Function(recv, types.String("Hi "))
+	if s, ok := result.Value().(string); !ok || s != "Hi Bob" {
+		t.Errorf("Greet(\"Hi \") = %v, want %q", result.Value(), "Hi Bob")
+	}
+}
+
+type nativeTestInner struct {
+	Name string
+}
+
+type nativeTestOuter struct {
+	Name string
+	nativeTestInner
+}
+
+func TestMakeFieldMapShallowerWins(t *testing.T) {
+	fieldMap := makeFieldMap(reflect.TypeOf(nativeTestOuter{}), false)
+	f, ok := fieldMap["Name"]
+	if !ok {
+		t.Fatal("expected Name field to be present")
+	}
+	if len(f.Index) != 1 {
+		t.Errorf("expected the outer, shallower Name field to win over the promoted one, got index %v", f.Index)
+	}
+}
+
+func TestIsFieldSetNilInterface(t *testing.T) {
+	var iface any
+	refField := reflect.ValueOf(&iface).Elem()
+	if isFieldSet(refField, true) {
+		t.Error("a nil interface with omitempty must report unset")
+	}
+	iface = 0
+	if !isFieldSet(refField, true) {
+		t.Error("a non-nil interface holding a zero value must report set")
+	}
+}
+
+func TestDiscoverPackageTypesThroughWrapper(t *testing.T) {
+	type wrapper struct {
+		nativetest.Leaf
+	}
+	pkgPath := reflect.TypeOf(nativetest.Leaf{}).PkgPath()
+	visited := make(map[reflect.Type]bool)
+	var found []reflect.Type
+	discoverPackageTypes(pkgPath, reflect.TypeOf(wrapper{}), visited, &found)
+	if len(found) != 1 || found[0] != reflect.TypeOf(nativetest.Leaf{}) {
+		t.Errorf("expected to discover nativetest.Leaf through a wrapper outside its package, got %v", found)
+	}
+}
+
+func TestDiscoverPackageTypesCycle(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	pkgPath := reflect.TypeOf(node{}).PkgPath()
+	visited := make(map[reflect.Type]bool)
+	var found []reflect.Type
+	discoverPackageTypes(pkgPath, reflect.TypeOf(node{}), visited, &found)
+	if len(found) != 1 {
+		t.Errorf("expected a self-referential struct to be discovered exactly once, got %d", len(found))
+	}
+}
+
+type nativeTestJSONTagged struct {
+	Name string `json:"name"`
+}
+
+func TestNativeTypesFromPackageDefersOptionApplication(t *testing.T) {
+	pkgPath := reflect.TypeOf(nativeTestJSONTagged{}).PkgPath()
+	tp, err := newNativeTypeProvider(types.DefaultTypeAdapter, nil,
+		NativeTypesFromPackage(pkgPath, nativeTestJSONTagged{}),
+		NativeTypesUseJSONTag(),
+	)
+	if err != nil {
+		t.Fatalf("newNativeTypeProvider() failed: %v", err)
+	}
+	typeName := fmt.Sprintf("%s.nativeTestJSONTagged", simplePkgAlias(pkgPath))
+	nt, found := tp.nativeTypes[typeName]
+	if !found {
+		t.Fatalf("expected %s to be registered, got %v", typeName, tp.nativeTypes)
+	}
+	if _, ok := nt.fieldMap["name"]; !ok {
+		t.Errorf("expected a json tag fallback applied after NativeTypesFromPackage to rename Name to name, got %v", nt.fieldMap)
+	}
+}
+
+func TestNativeEnum(t *testing.T) {
+	type status int32
+	const (
+		statusActive status = iota
+		statusInactive
+	)
+	tp, err := newNativeTypeProvider(types.DefaultTypeAdapter, nil,
+		NativeEnum(status(0), map[string]any{
+			"Active":   statusActive,
+			"Inactive": statusInactive,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("newNativeTypeProvider() failed: %v", err)
+	}
+	typeName := fmt.Sprintf("%s.status", simplePkgAlias(reflect.TypeOf(status(0)).PkgPath()))
+	if _, found := tp.FindIdent(typeName); !found {
+		t.Errorf("expected enum namespace %s to resolve via FindIdent", typeName)
+	}
+	got := tp.EnumValue(typeName + ".Active")
+	want := types.Int(int64(statusActive))
+	if got.Equal(want) != types.True {
+		t.Errorf("EnumValue(%q) = %v, want %v", typeName+".Active", got.Value(), want.Value())
+	}
+}
+
+func TestNativeEnumMismatchedValueType(t *testing.T) {
+	type status int32
+	_, err := newNativeTypeProvider(types.DefaultTypeAdapter, nil,
+		NativeEnum(status(0), map[string]any{
+			"Bad": "not-a-status",
+		}),
+	)
+	if err == nil {
+		t.Error("expected an error for an enum value whose type does not match the zero value's type")
+	}
+}
+
+type e2eCalculator struct {
+	Base int64
+}
+
+func (c *e2eCalculator) Add(n int64) int64 {
+	return c.Base + n
+}
+
+func (c *e2eCalculator) Sum(ns ...int64) int64 {
+	total := c.Base
+	for _, n := range ns {
+		total += n
+	}
+	return total
+}
+
+func TestNativeTypesMethodsE2E(t *testing.T) {
+	env, err := cel.NewEnv(
+		NativeTypes(NativeTypesWithMethods(), reflect.TypeOf(e2eCalculator{})),
+		cel.Declarations(decls.NewVar("c", decls.NewObjectType("ext.e2eCalculator"))),
+	)
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	tests := []struct {
+		name    string
+		expr    string
+		want    any
+		wantErr string
+	}{
+		{
+			name: "ordinary method dispatch",
+			expr: "c.Add(5)",
+			want: int64(15),
+		},
+		{
+			// Variadic Go methods are exposed to CEL with their trailing parameter declared as a
+			// list, matching the Go slice CallSlice is invoked with, so a CEL list literal is the
+			// natural call syntax rather than unpacked arguments.
+			name: "variadic method called with a CEL list",
+			expr: "c.Sum([1, 2, 3])",
+			want: int64(16),
+		},
+		{
+			name:    "variadic method cannot be called with unpacked arguments",
+			expr:    "c.Sum(1, 2, 3)",
+			wantErr: "no matching overload",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evalNativeExpr(t, env, tc.expr, map[string]any{"c": &e2eCalculator{Base: 10}})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got (%v, %v), want error containing %q", got, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("eval(%q) failed: %v", tc.expr, err)
+			}
+			if got != tc.want {
+				t.Errorf("eval(%q) = %v, want %v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+type e2eEmbedded struct {
+	City string
+}
+
+type e2ePerson struct {
+	Name string
+	e2eEmbedded
+	Spec any
+}
+
+// e2ePersonOmit pairs an omitempty-tagged interface field with has(), since has() only falls
+// back to a zero/nil check for fields that opt into omitempty; a field without the tag is always
+// considered set (see isFieldSet).
+type e2ePersonOmit struct {
+	Name string
+	Spec any `cel:",omitempty"`
+}
+
+func TestNativeTypesEmbeddingAndInterfaceE2E(t *testing.T) {
+	env, err := cel.NewEnv(
+		NativeTypes(reflect.TypeOf(e2ePerson{}), reflect.TypeOf(e2ePersonOmit{})),
+		cel.Declarations(
+			decls.NewVar("p", decls.NewObjectType("ext.e2ePerson")),
+			decls.NewVar("po", decls.NewObjectType("ext.e2ePersonOmit")),
+		),
+	)
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		expr string
+		vars map[string]any
+		want any
+	}{
+		{
+			name: "promoted embedded field",
+			expr: "p.City",
+			vars: map[string]any{"p": &e2ePerson{Name: "Ann", e2eEmbedded: e2eEmbedded{City: "NYC"}}},
+			want: "NYC",
+		},
+		{
+			name: "nil interface field with omitempty is unset",
+			expr: "has(po.Spec)",
+			vars: map[string]any{"po": &e2ePersonOmit{Name: "Ann"}},
+			want: false,
+		},
+		{
+			name: "non-nil interface field with omitempty is set",
+			expr: "has(po.Spec)",
+			vars: map[string]any{"po": &e2ePersonOmit{Name: "Ann", Spec: "x"}},
+			want: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evalNativeExpr(t, env, tc.expr, tc.vars)
+			if err != nil {
+				t.Fatalf("eval(%q) failed: %v", tc.expr, err)
+			}
+			if got != tc.want {
+				t.Errorf("eval(%q) = %v, want %v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNativeObjConvertToNativeJSONNilInterfaceE2E(t *testing.T) {
+	env, err := cel.NewEnv(NativeTypes(reflect.TypeOf(e2ePerson{})))
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	tp, err := newNativeTypeProvider(env.TypeAdapter(), env.TypeProvider(), reflect.TypeOf(e2ePerson{}))
+	if err != nil {
+		t.Fatalf("newNativeTypeProvider() failed: %v", err)
+	}
+	val := tp.NativeToValue(&e2ePerson{Name: "Ann"})
+	if _, err := val.ConvertToNative(jsonStructType); err != nil {
+		t.Fatalf("ConvertToNative(jsonStructType) on a native object with an unset interface field failed: %v", err)
+	}
+}
+
+type e2ePkgLeaf struct {
+	Label string
+}
+
+type e2ePkgRoot struct {
+	Leaf e2ePkgLeaf
+}
+
+func TestNativeTypesFromPackageE2E(t *testing.T) {
+	pkgPath := reflect.TypeOf(e2ePkgRoot{}).PkgPath()
+	env, err := cel.NewEnv(
+		NativeTypes(NativeTypesFromPackage(pkgPath, e2ePkgRoot{})),
+		cel.Declarations(decls.NewVar("r", decls.NewObjectType("ext.e2ePkgRoot"))),
+	)
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	got, err := evalNativeExpr(t, env, "r.Leaf.Label", map[string]any{"r": &e2ePkgRoot{Leaf: e2ePkgLeaf{Label: "x"}}})
+	if err != nil {
+		t.Fatalf("eval() failed: %v", err)
+	}
+	if got != "x" {
+		t.Errorf("eval() = %v, want %q", got, "x")
+	}
+}
+
+type e2eStatus int
+
+func TestNativeEnumE2E(t *testing.T) {
+	const (
+		e2eStatusActive e2eStatus = iota
+		e2eStatusInactive
+	)
+	env, err := cel.NewEnv(
+		NativeTypes(
+			NativeEnum(e2eStatus(0), map[string]any{
+				"Active":   e2eStatusActive,
+				"Inactive": e2eStatusInactive,
+			}),
+		),
+	)
+	if err != nil {
+		t.Fatalf("cel.NewEnv() failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{name: "plain int enum identifier", expr: "ext.e2eStatus.Active == 0", want: true},
+		{name: "plain int enum mismatch", expr: "ext.e2eStatus.Active == ext.e2eStatus.Inactive", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evalNativeExpr(t, env, tc.expr, nil)
+			if err != nil {
+				t.Fatalf("eval(%q) failed: %v", tc.expr, err)
+			}
+			if got != tc.want {
+				t.Errorf("eval(%q) = %v, want %v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+// e2eColor is a string-kind enum. Unlike the int-kind case above, a qualified reference to one of
+// its members (e.g. ext.e2eColor.Red) cannot be exercised through cel.NewEnv/Compile: the CEL
+// checker's own enum-import fallback assumes the resolved value is always a 64-bit int and panics
+// for any other kind, a limitation documented on NativeEnum. This test instead confirms, at the
+// ref.TypeProvider level NativeTypes itself relies on, that a string-kind member now resolves to
+// its correct value rather than the unsupported-conversion error it produced before nativeEnumValue
+// existed.
+type e2eColor string
+
+func TestNativeEnumStringKindValue(t *testing.T) {
+	const (
+		e2eColorRed   e2eColor = "red"
+		e2eColorGreen e2eColor = "green"
+	)
+	tp, err := newNativeTypeProvider(types.DefaultTypeAdapter, nil,
+		NativeEnum(e2eColor(""), map[string]any{
+			"Red":   e2eColorRed,
+			"Green": e2eColorGreen,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("newNativeTypeProvider() failed: %v", err)
+	}
+	typeName := fmt.Sprintf("%s.e2eColor", simplePkgAlias(reflect.TypeOf(e2eColor("")).PkgPath()))
+	got := tp.EnumValue(typeName + ".Red")
+	if s, ok := got.Value().(string); !ok || s != "red" {
+		t.Errorf("EnumValue(%q) = %v, want %q", typeName+".Red", got.Value(), "red")
+	}
+}